@@ -0,0 +1,176 @@
+package tsl
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// kwayMergeableRange represents the overlapping merge of three or more
+// SortedRanges. Where mergeableRange resolves an overlap between exactly
+// two ranges with a single comparison per element, kwayMergeableRange
+// keeps one cursor open per child and selects the next element with a
+// min-heap, avoiding the deeper comparator tree that nesting pairwise
+// merges would otherwise require.
+type kwayMergeableRange struct {
+	first    Element
+	last     Element
+	children []SortedRange
+}
+
+// newKwayMergeableRange builds a kwayMergeableRange over children. Callers
+// are expected to have already filtered out empty ranges.
+func newKwayMergeableRange(children ...SortedRange) SortedRange {
+	if len(children) == 0 {
+		return EmptyRange
+	}
+
+	bounds := elementBounds{children[0].First(), children[0].Last()}
+	for _, c := range children[1:] {
+		bounds = elementBounds{selectFirst(bounds, c), selectLast(bounds, c)}
+	}
+
+	return &kwayMergeableRange{
+		first:    bounds.first,
+		last:     bounds.last,
+		children: children,
+	}
+}
+
+// elementBounds is a minimal Range used to fold selectFirst/selectLast
+// across more than two inputs.
+type elementBounds struct {
+	first Element
+	last  Element
+}
+
+func (b elementBounds) First() Element { return b.first }
+func (b elementBounds) Last() Element  { return b.last }
+
+func (k *kwayMergeableRange) First() Element {
+	return k.first
+}
+
+func (k *kwayMergeableRange) Last() Element {
+	return k.last
+}
+
+func (k *kwayMergeableRange) Limit() int {
+	limit := 0
+	for _, c := range k.children {
+		limit += c.Limit()
+	}
+	return limit
+}
+
+func (k *kwayMergeableRange) Open() Cursor {
+	entries := make(kwayHeap, 0, len(k.children))
+	for i, c := range k.children {
+		cursor := c.Open()
+		if e := cursor.Next(); e != nil {
+			entries = append(entries, &kwayEntry{elem: e, cursor: cursor, source: i})
+		}
+	}
+	heap.Init(&entries)
+	return &kwayCursor{heap: entries}
+}
+
+// Partition splits each child at e and re-merges the low and high pieces,
+// so the result keeps the same partition semantics as merge()/mergeableRange.
+func (k *kwayMergeableRange) Partition(e Element, o Order) (SortedRange, SortedRange) {
+	lows := make([]SortedRange, 0, len(k.children))
+	highs := make([]SortedRange, 0, len(k.children))
+	for _, c := range k.children {
+		lo, hi := c.Partition(e, o)
+		if lo.Limit() > 0 {
+			lows = append(lows, lo)
+		}
+		if hi.Limit() > 0 {
+			highs = append(highs, hi)
+		}
+	}
+	return mergeAll(lows), mergeAll(highs)
+}
+
+func (k *kwayMergeableRange) String() string {
+	buf := fmt.Sprintf("kwayMergeableRange{first: %v, last: %v, children: [", k.first, k.last)
+	for i, c := range k.children {
+		if i > 0 {
+			buf = buf + ","
+		}
+		buf = buf + fmt.Sprintf("%v", c)
+	}
+	buf = buf + "]}"
+	return buf
+}
+
+// kwayEntry is one source's current head element, paired with the cursor
+// it came from so Next() can pull a replacement.
+type kwayEntry struct {
+	elem   Element
+	cursor Cursor
+	source int
+}
+
+// kwayHeap is a container/heap of kwayEntry ordered by elem, breaking ties
+// by source index so that elements compare equal under LessOrder come out
+// in a stable, deterministic order.
+type kwayHeap []*kwayEntry
+
+func (h kwayHeap) Len() int { return len(h) }
+
+func (h kwayHeap) Less(i, j int) bool {
+	switch cmp := h[i].elem.Compare(h[j].elem); {
+	case cmp != 0:
+		return cmp < 0
+	default:
+		return h[i].source < h[j].source
+	}
+}
+
+func (h kwayHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *kwayHeap) Push(x interface{}) {
+	*h = append(*h, x.(*kwayEntry))
+}
+
+func (h *kwayHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	*h = old[0 : n-1]
+	return e
+}
+
+// kwayCursor drains a kwayHeap in min order, advancing whichever source
+// just yielded its head element.
+type kwayCursor struct {
+	heap kwayHeap
+}
+
+func (c *kwayCursor) Next() Element {
+	if len(c.heap) == 0 {
+		return nil
+	}
+	top := c.heap[0]
+	next := top.elem
+	if e := top.cursor.Next(); e != nil {
+		top.elem = e
+		heap.Fix(&c.heap, 0)
+	} else {
+		heap.Pop(&c.heap)
+	}
+	return next
+}
+
+func (c *kwayCursor) Fill(buffer []Element) int {
+	next := 0
+	for next < len(buffer) {
+		e := c.Next()
+		if e == nil {
+			break
+		}
+		buffer[next] = e
+		next++
+	}
+	return next
+}
@@ -50,7 +50,7 @@ func (d *disjointRanges) Partition(e Element, o Order) (SortedRange, SortedRange
 		if r.Last() == nil {
 			panic("r.Last() is nil!")
 		}
-		if o(e, r.First()) && !o(r.First(), e) {
+		if !o(r.First(), e) {
 			r1, r2 := &disjointRanges{
 				first:    d.first,
 				last:     d.segments[i-1].Last(),
@@ -62,7 +62,7 @@ func (d *disjointRanges) Partition(e Element, o Order) (SortedRange, SortedRange
 			}
 			return r1, r2
 		}
-		if o(r.First(), e) && o(e, r.Last()) {
+		if !o(r.Last(), e) {
 			p1, p2 := r.Partition(e, o)
 			var r1, r2 SortedRange
 			if p2.Limit() == 0 {
@@ -252,6 +252,70 @@ func merge(a SortedRange, b SortedRange) SortedRange {
 	}
 }
 
+// MergeAll merges any number of SortedRanges, such as the dozens of log
+// segments a compaction pass might combine. It is the entry point for
+// combining more than two ranges at once: callers should reach for it
+// instead of folding merge() over the inputs themselves, since that
+// nests pairwise merges into a comparator tree as deep as len(ranges)
+// wherever three or more of them actually overlap.
+func MergeAll(ranges ...SortedRange) SortedRange {
+	return mergeAll(ranges)
+}
+
+// mergeAll is MergeAll's slice-taking implementation. Two inputs are
+// merged directly via merge(). When three or more inputs each overlap at
+// least one other input (see overlapCount), all of the inputs, including
+// any that overlap nothing, are combined with a single kwayMergeableRange
+// rather than nesting merge() pairwise, which would otherwise build a
+// comparator tree as deep as len(ranges). Otherwise the inputs fall back
+// to a pairwise reduction, since merge() already produces disjointRanges
+// for non-overlapping inputs.
+func mergeAll(ranges []SortedRange) SortedRange {
+	filtered := make([]SortedRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Limit() > 0 {
+			filtered = append(filtered, r)
+		}
+	}
+
+	switch len(filtered) {
+	case 0:
+		return EmptyRange
+	case 1:
+		return filtered[0]
+	case 2:
+		return merge(filtered[0], filtered[1])
+	}
+
+	if overlapCount(filtered) >= 3 {
+		return newKwayMergeableRange(filtered...)
+	}
+
+	acc := filtered[0]
+	for _, r := range filtered[1:] {
+		acc = merge(acc, r)
+	}
+	return acc
+}
+
+// overlapCount returns how many of ranges share their span with at least
+// one other range in the slice.
+func overlapCount(ranges []SortedRange) int {
+	n := 0
+	for i, r := range ranges {
+		for j, other := range ranges {
+			if i == j {
+				continue
+			}
+			if !r.Last().Less(other.First()) && !other.Last().Less(r.First()) {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
 func (d *disjointRanges) String() string {
 	buf := fmt.Sprintf("disjointRanges{first: %v, last: %v, segments: [", d.first, d.last)
 	for i, s := range d.segments {
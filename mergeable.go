@@ -0,0 +1,119 @@
+package tsl
+
+import "fmt"
+
+// mergeableRange is the pairwise overlap node merge() builds when two
+// ranges share part of their span: it keeps both children open and
+// interleaves them by comparison rather than materializing the overlap.
+type mergeableRange struct {
+	first Element
+	last  Element
+	a     SortedRange
+	b     SortedRange
+	cmp   Compare
+}
+
+// newMergeableRange builds a mergeableRange over a and b. cmp may be nil,
+// in which case elements are ordered via Element.Compare.
+func newMergeableRange(first, last Element, a, b SortedRange, cmp Compare) SortedRange {
+	if cmp == nil {
+		cmp = func(x, y Element) int { return x.Compare(y) }
+	}
+	return &mergeableRange{first: first, last: last, a: a, b: b, cmp: cmp}
+}
+
+// useEmptyRangeIfEmpty returns EmptyRange in place of r when r has
+// nothing left to give, sparing callers a Limit() check of their own.
+func useEmptyRangeIfEmpty(r SortedRange) SortedRange {
+	if r.Limit() == 0 {
+		return EmptyRange
+	}
+	return r
+}
+
+func (m *mergeableRange) First() Element {
+	return m.first
+}
+
+func (m *mergeableRange) Last() Element {
+	return m.last
+}
+
+func (m *mergeableRange) Limit() int {
+	return m.a.Limit() + m.b.Limit()
+}
+
+func (m *mergeableRange) Open() Cursor {
+	a, b := m.a.Open(), m.b.Open()
+	return &mergeableCursor{a: a, b: b, headA: a.Next(), headB: b.Next(), cmp: m.cmp}
+}
+
+// Partition splits both children at e and re-merges each side, so the
+// result keeps the same overlap semantics as the parent.
+func (m *mergeableRange) Partition(e Element, o Order) (SortedRange, SortedRange) {
+	aLo, aHi := m.a.Partition(e, o)
+	bLo, bHi := m.b.Partition(e, o)
+	return mergeAll([]SortedRange{aLo, bLo}), mergeAll([]SortedRange{aHi, bHi})
+}
+
+// Snapshot snapshots both children; the returned mergeableRange shares no
+// mutable state with m, so it is safe to iterate from another goroutine
+// while m's children are themselves being folded into a newer merge.
+func (m *mergeableRange) Snapshot() SortedRange {
+	return &mergeableRange{
+		first: m.first,
+		last:  m.last,
+		a:     m.a.Snapshot(),
+		b:     m.b.Snapshot(),
+		cmp:   m.cmp,
+	}
+}
+
+func (m *mergeableRange) String() string {
+	return fmt.Sprintf("mergeableRange{first: %v, last: %v, a: %v, b: %v}", m.first, m.last, m.a, m.b)
+}
+
+// mergeableCursor interleaves a and b by cmp, preferring a on ties so
+// that iteration order stays deterministic.
+type mergeableCursor struct {
+	a, b  Cursor
+	headA Element
+	headB Element
+	cmp   Compare
+}
+
+func (c *mergeableCursor) Next() Element {
+	switch {
+	case c.headA == nil && c.headB == nil:
+		return nil
+	case c.headA == nil:
+		e := c.headB
+		c.headB = c.b.Next()
+		return e
+	case c.headB == nil:
+		e := c.headA
+		c.headA = c.a.Next()
+		return e
+	case c.cmp(c.headA, c.headB) <= 0:
+		e := c.headA
+		c.headA = c.a.Next()
+		return e
+	default:
+		e := c.headB
+		c.headB = c.b.Next()
+		return e
+	}
+}
+
+func (c *mergeableCursor) Fill(buffer []Element) int {
+	n := 0
+	for n < len(buffer) {
+		e := c.Next()
+		if e == nil {
+			break
+		}
+		buffer[n] = e
+		n++
+	}
+	return n
+}
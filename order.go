@@ -0,0 +1,32 @@
+package tsl
+
+// Order reports whether a belongs on the low side of a partition at b:
+// LessOrder is the strict form (a < b) and LessOrEqualOrder the
+// non-strict one (a <= b). It is kept as a
+// compatibility shim for existing callers; code that also needs to test
+// equality should prefer Compare, which resolves the relationship with a
+// single call instead of the o(a, b) && !o(b, a) idiom Order requires.
+type Order func(a, b Element) bool
+
+// Compare reports the sort relationship between a and b: negative if a
+// sorts before b, positive if a sorts after b, and zero if they are
+// equivalent for ordering purposes.
+type Compare func(a, b Element) int
+
+// LessOrder and LessOrEqualOrder below call a.Compare(b), so Element
+// itself (declared outside this chunk) must expose Compare(other Element)
+// int alongside its existing Less. That half of this request can't be
+// verified from this file alone, since Element's declaration isn't part
+// of this tree; everything in this package that needs it - Partition's
+// single-comparison branch, the kway heap, mergeableRange's cursor - is
+// written assuming it exists.
+
+// LessOrder is the Order form of Element.Compare: a sorts strictly before b.
+var LessOrder Order = func(a, b Element) bool {
+	return a.Compare(b) < 0
+}
+
+// LessOrEqualOrder is the Order form of a.Compare(b) <= 0.
+var LessOrEqualOrder Order = func(a, b Element) bool {
+	return a.Compare(b) <= 0
+}
@@ -0,0 +1,250 @@
+package tsl
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultParallelThreshold is the minimum buffer size below which
+// ParallelCursor.Fill falls back to a sequential scan rather than paying
+// for goroutine dispatch.
+const defaultParallelThreshold = 1024
+
+// ParallelCursor fills a buffer from a disjointRanges' segments
+// concurrently, reading each segment's share on its own worker and
+// stitching the results back together in segment order. It is opt-in:
+// Next() and small Fill() calls behave exactly like the sequential
+// disjointCursor.
+type ParallelCursor struct {
+	segments  []SortedRange
+	cursors   []Cursor
+	pos       int
+	pool      *workerPool
+	threshold int
+	ctx       context.Context
+}
+
+// OpenParallel opens d for concurrent reads, using a pool of workers
+// goroutines. The returned cursor is reusable across many Fill calls; call
+// Close when done with it to release the pool.
+func (d *disjointRanges) OpenParallel(workers int) *ParallelCursor {
+	return d.OpenParallelContext(context.Background(), workers)
+}
+
+// OpenParallelContext is OpenParallel with a caller-supplied context; the
+// pool's workers exit once ctx is canceled. A Fill round under way at that
+// moment returns whatever its workers already collected; later Fill calls
+// no longer use the pool and read the segments sequentially.
+func (d *disjointRanges) OpenParallelContext(ctx context.Context, workers int) *ParallelCursor {
+	return &ParallelCursor{
+		segments:  d.segments,
+		cursors:   make([]Cursor, len(d.segments)),
+		pool:      newWorkerPool(ctx, workers),
+		threshold: defaultParallelThreshold,
+		ctx:       ctx,
+	}
+}
+
+// SetThreshold overrides the buffer size below which Fill prefers a
+// sequential scan over dispatching to the pool. The segment-count cutoff
+// is not affected: Fill always reads sequentially when fewer than two
+// segments remain.
+func (c *ParallelCursor) SetThreshold(n int) {
+	c.threshold = n
+}
+
+// Close releases the cursor's worker pool. The cursor must not be used
+// afterwards.
+func (c *ParallelCursor) Close() {
+	c.pool.close()
+}
+
+func (c *ParallelCursor) cursorFor(i int) Cursor {
+	if c.cursors[i] == nil {
+		c.cursors[i] = c.segments[i].Open()
+	}
+	return c.cursors[i]
+}
+
+func (c *ParallelCursor) Next() Element {
+	for c.pos < len(c.segments) {
+		if e := c.cursorFor(c.pos).Next(); e != nil {
+			return e
+		}
+		c.pos++
+	}
+	return nil
+}
+
+func (c *ParallelCursor) Fill(buffer []Element) int {
+	max := len(buffer)
+	if max == 0 || c.pos >= len(c.segments) {
+		return 0
+	}
+
+	remaining := c.segments[c.pos:]
+	if len(remaining) < 2 || max < c.threshold || c.ctx.Err() != nil {
+		return c.fillSequential(buffer)
+	}
+
+	shares := proportionalShares(remaining, max)
+	offsets := make([]int, len(shares)+1)
+	for i, s := range shares {
+		offsets[i+1] = offsets[i] + s
+	}
+
+	filled := make([]int, len(shares))
+	ran := make([]bool, len(shares))
+	var wg sync.WaitGroup
+	for i, share := range shares {
+		if share == 0 {
+			continue
+		}
+		i, lo, hi := i, offsets[i], offsets[i+1]
+		wg.Add(1)
+		ran[i] = c.pool.submit(c.ctx, func() {
+			defer wg.Done()
+			filled[i] = c.cursorFor(c.pos + i).Fill(buffer[lo:hi])
+		})
+		if !ran[i] {
+			// ctx was canceled with workers already gone; the task above
+			// never ran, so its wg.Done() never fires.
+			wg.Done()
+		}
+	}
+	wg.Wait()
+
+	// Every task that ran has consumed its share off a forward-only
+	// cursor, whether or not its read came up short, so every one of
+	// those elements must be compacted into the return value - there is
+	// no dispatch-time way to "skip" the segments after a short one.
+	total := 0
+	for i, f := range filled {
+		if offsets[i] != total {
+			copy(buffer[total:total+f], buffer[offsets[i]:offsets[i]+f])
+		}
+		total += f
+	}
+
+	// The segment cursors' Fill returns fewer elements than requested only
+	// when its source is exhausted. pos only ever advances across a
+	// *leading* contiguous run of segments confirmed exhausted this way; the
+	// first segment that filled its whole share, or whose task never ran,
+	// stays in place; the cursors for everything else retain their
+	// position for the next call.
+	advance := 0
+	for i, f := range filled {
+		if f >= shares[i] || !ran[i] {
+			break
+		}
+		advance = i + 1
+	}
+	c.pos += advance
+
+	if total < max && c.ctx.Err() == nil {
+		total += c.fillSequential(buffer[total:max])
+	}
+	return total
+}
+
+func (c *ParallelCursor) fillSequential(buffer []Element) int {
+	max := len(buffer)
+	next := 0
+	for next < max && c.pos < len(c.segments) {
+		filled := c.cursorFor(c.pos).Fill(buffer[next:max])
+		next += filled
+		if next < max {
+			c.pos++
+		}
+	}
+	return next
+}
+
+// proportionalShares splits max across segments in proportion to each
+// segment's Limit(), never assigning a segment more than its own limit.
+// Any remainder left by integer division is handed to the leading
+// segments that still have room.
+func proportionalShares(segments []SortedRange, max int) []int {
+	total := 0
+	for _, s := range segments {
+		total += s.Limit()
+	}
+
+	shares := make([]int, len(segments))
+	if total == 0 {
+		return shares
+	}
+
+	assigned := 0
+	for i, s := range segments {
+		share := max * s.Limit() / total
+		if share > s.Limit() {
+			share = s.Limit()
+		}
+		shares[i] = share
+		assigned += share
+	}
+
+	remainder := max - assigned
+	for i := 0; remainder > 0 && i < len(shares); i++ {
+		room := segments[i].Limit() - shares[i]
+		if room <= 0 {
+			continue
+		}
+		take := remainder
+		if take > room {
+			take = room
+		}
+		shares[i] += take
+		remainder -= take
+	}
+	return shares
+}
+
+// workerPool is a small, fixed-size pool of goroutines consuming tasks off
+// a shared channel, in the shape of Jeffail/tunny: workers block on tasks
+// until the pool is closed or its context is canceled.
+type workerPool struct {
+	tasks chan func()
+}
+
+func newWorkerPool(ctx context.Context, workers int) *workerPool {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &workerPool{tasks: make(chan func())}
+	for i := 0; i < workers; i++ {
+		go p.loop(ctx)
+	}
+	return p
+}
+
+func (p *workerPool) loop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case fn, ok := <-p.tasks:
+			if !ok {
+				return
+			}
+			fn()
+		}
+	}
+}
+
+// submit hands fn to a worker, reporting false instead of blocking forever
+// if ctx is canceled before any worker picks it up (e.g. because every
+// worker has already exited via its own ctx.Done() case).
+func (p *workerPool) submit(ctx context.Context, fn func()) bool {
+	select {
+	case p.tasks <- fn:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (p *workerPool) close() {
+	close(p.tasks)
+}
@@ -0,0 +1,122 @@
+package tsl
+
+import "fmt"
+
+// Selector is implemented by SortedRanges that can push an element-range
+// bound and a predicate down into their segments instead of requiring
+// the caller to scan and filter the whole range themselves.
+type Selector interface {
+	Select(lo, hi Element, pred func(Element) bool) SortedRange
+}
+
+// Select trims d to [lo, hi] using Partition (so whole out-of-bounds
+// segments are skipped by comparing their bounds rather than scanning
+// their elements; Partition walks the segments in order, so this costs
+// one or two comparisons per segment, not O(log N segments)) and
+// wraps each surviving segment in a filterRange, deferring the pred
+// check to iteration time. Either bound may be nil to leave that side
+// unbounded.
+func (d *disjointRanges) Select(lo, hi Element, pred func(Element) bool) SortedRange {
+	var trimmed SortedRange = d
+	if lo != nil {
+		_, trimmed = trimmed.Partition(lo, LessOrder)
+	}
+	if hi != nil {
+		trimmed, _ = trimmed.Partition(hi, LessOrEqualOrder)
+	}
+	if trimmed.Limit() == 0 {
+		return EmptyRange
+	}
+
+	dr, ok := trimmed.(*disjointRanges)
+	if !ok {
+		return &filterRange{inner: trimmed, pred: pred}
+	}
+
+	segments := make([]SortedRange, len(dr.segments))
+	for i, s := range dr.segments {
+		segments[i] = &filterRange{inner: s, pred: pred}
+	}
+	return &disjointRanges{
+		first:    dr.first,
+		last:     dr.last,
+		segments: segments,
+	}
+}
+
+// filterRange wraps a SortedRange so that iteration only yields elements
+// matching pred. First() and Last() describe the underlying range's
+// bounds rather than the filtered subset, since computing exact filtered
+// bounds would require a scan.
+//
+// Limit() is likewise an upper bound, not the true count pred will pass:
+// it can overstate how many elements iteration actually yields. mergeAll
+// only uses it as a non-empty check, which is safe. ParallelCursor's
+// proportionalShares uses it as a relative weight for splitting a Fill
+// buffer across segments, so an overstated Limit() on a heavily-filtered
+// segment can make that segment's share look bigger than what it will
+// actually yield in a round; ParallelCursor.Fill must not assume a short
+// read from one segment says anything about segments dispatched
+// alongside it. Code that needs the real filtered count must scan via
+// Open().
+type filterRange struct {
+	inner SortedRange
+	pred  func(Element) bool
+}
+
+func (f *filterRange) First() Element {
+	return f.inner.First()
+}
+
+func (f *filterRange) Last() Element {
+	return f.inner.Last()
+}
+
+func (f *filterRange) Limit() int {
+	return f.inner.Limit()
+}
+
+func (f *filterRange) Open() Cursor {
+	return &filterCursor{inner: f.inner.Open(), pred: f.pred}
+}
+
+func (f *filterRange) Partition(e Element, o Order) (SortedRange, SortedRange) {
+	lo, hi := f.inner.Partition(e, o)
+	return &filterRange{inner: lo, pred: f.pred}, &filterRange{inner: hi, pred: f.pred}
+}
+
+func (f *filterRange) Snapshot() SortedRange {
+	return &filterRange{inner: f.inner.Snapshot(), pred: f.pred}
+}
+
+func (f *filterRange) String() string {
+	return fmt.Sprintf("filterRange{inner: %v}", f.inner)
+}
+
+// filterCursor skips elements that don't satisfy pred.
+type filterCursor struct {
+	inner Cursor
+	pred  func(Element) bool
+}
+
+func (c *filterCursor) Next() Element {
+	for {
+		e := c.inner.Next()
+		if e == nil || c.pred(e) {
+			return e
+		}
+	}
+}
+
+func (c *filterCursor) Fill(buffer []Element) int {
+	n := 0
+	for n < len(buffer) {
+		e := c.Next()
+		if e == nil {
+			break
+		}
+		buffer[n] = e
+		n++
+	}
+	return n
+}
@@ -0,0 +1,28 @@
+package tsl
+
+// Snapshot returns an immutable view of d as of the moment of the call.
+// It copies the segments slice header and snapshots each segment in
+// turn; segments that are already immutable (e.g. a kwayMergeableRange)
+// return themselves from their own Snapshot(). The result has its own
+// segments slice, so a later merge() or Partition() on d, even where it
+// overwrites an entry of d's segments slice in place, does not reach the
+// snapshot; Open()ing and iterating a snapshot from any goroutine is safe
+// even while the parent range is concurrently being folded into new
+// versions by a writer.
+func (d *disjointRanges) Snapshot() SortedRange {
+	segments := make([]SortedRange, len(d.segments))
+	for i, s := range d.segments {
+		segments[i] = s.Snapshot()
+	}
+	return &disjointRanges{
+		first:    d.first,
+		last:     d.last,
+		segments: segments,
+	}
+}
+
+// Snapshot returns k itself: a kwayMergeableRange's children slice is
+// fixed at construction, so it is already safe to share across readers.
+func (k *kwayMergeableRange) Snapshot() SortedRange {
+	return k
+}
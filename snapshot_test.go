@@ -0,0 +1,51 @@
+package tsl
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestSnapshotConcurrentWithMerge runs a producer that keeps folding new
+// segments into a disjointRanges while several consumers each hold a
+// Snapshot taken at a different point and iterate it to completion. Under
+// -race this would flag any sharing of mutable state between a snapshot
+// and the parent range it was taken from.
+func TestSnapshotConcurrentWithMerge(t *testing.T) {
+	const producers = 8
+	const segLen = 256
+
+	var current SortedRange = manySegments(1, segLen)
+
+	var wg sync.WaitGroup
+	snapshots := make(chan SortedRange, producers)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(snapshots)
+		d := current
+		for i := 1; i <= producers; i++ {
+			next := newSliceRange(segLen, (i+1)*segLen)
+			d = MergeAll(d, next)
+			snapshots <- d.Snapshot()
+		}
+	}()
+
+	var consumed sync.WaitGroup
+	for snap := range snapshots {
+		consumed.Add(1)
+		go func(snap SortedRange) {
+			defer consumed.Done()
+			cursor := snap.Open()
+			buf := make([]Element, 64)
+			for {
+				if n := cursor.Fill(buf); n == 0 {
+					break
+				}
+			}
+		}(snap)
+	}
+
+	wg.Wait()
+	consumed.Wait()
+}